@@ -0,0 +1,38 @@
+// Copyright 2024 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+// craFramework exposes BSI TR-03183-2 through the Framework registry so it can
+// be listed and looked up alongside declaratively-loaded standards. Its checks
+// stay on the existing hardcoded craChecks path rather than the primitive
+// registry, since that's where the BSI-specific scoring rules already live;
+// only its section metadata is shared.
+type craFramework struct{}
+
+func (craFramework) ID() string { return FrameworkBSI }
+
+func (craFramework) Sections() map[int]Section {
+	sections := make(map[int]Section, len(craSectionDetails))
+	for key, s := range craSectionDetails {
+		sections[key] = Section{Title: s.Title, Id: s.Id, Required: s.Required, DataField: s.DataField}
+	}
+	return sections
+}
+
+func (craFramework) Checks() []Check { return nil }
+
+func init() {
+	Register(craFramework{})
+}
@@ -0,0 +1,216 @@
+// Copyright 2024 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"gopkg.in/yaml.v3"
+)
+
+// Section is the metadata a Framework declares for one of its check sections,
+// the same fields BSI/NTIA/Telco have always hardcoded in their own
+// craSectionDetails-style maps.
+type Section struct {
+	Title     string
+	Id        string
+	Required  bool
+	DataField string
+}
+
+// Check binds a Framework section to one of the reusable primitives already
+// implemented against sbom.Document (e.g. "primary_component.has_hash").
+// Weight multiplies the primitive's raw 0-10 score before it's recorded; zero
+// or unset is treated as 1.0 (see EvaluateFramework).
+type Check struct {
+	SectionKey int
+	Primitive  string
+	Params     map[string]interface{}
+	Weight     float64
+}
+
+// Framework is a pluggable compliance standard. BSI, NTIA and OpenChain Telco
+// implement it for their section metadata only: their Checks() lists are
+// empty, and their reports come from the per-standard paths in RunAll, Result
+// and SarifReport, which switch on the three built-in IDs. Only FrameworkReport
+// scores and renders a framework through this interface.
+type Framework interface {
+	ID() string
+	Sections() map[int]Section
+	Checks() []Check
+}
+
+var frameworkRegistry = map[string]Framework{}
+
+// Register adds f to the registry read by GetFramework, FrameworkIDs and
+// FrameworkReport. Re-registering an ID replaces the previous Framework, so a
+// YAML-loaded framework can override a built-in one in that registry. RunAll,
+// Result and SarifReport do not read the registry: they accept only the
+// built-in bsi, ntia and telco IDs and run those standards' own checks.
+func Register(f Framework) {
+	frameworkRegistry[f.ID()] = f
+}
+
+// GetFramework looks up a registered Framework by ID.
+func GetFramework(id string) (Framework, bool) {
+	f, ok := frameworkRegistry[id]
+	return f, ok
+}
+
+// FrameworkIDs returns every registered framework ID, sorted for stable output.
+func FrameworkIDs() []string {
+	ids := make([]string, 0, len(frameworkRegistry))
+	for id := range frameworkRegistry {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Primitive evaluates one reusable check against doc and returns the rendered
+// element result alongside its score out of 10.
+type Primitive func(doc sbom.Document, params map[string]interface{}) (result string, score float64)
+
+var primitiveRegistry = map[string]Primitive{
+	"primary_component.has_hash": primaryComponentHasHash,
+	"spec.version_in":            specVersionIn,
+	"component.license_present":  componentLicensePresent,
+}
+
+// RegisterPrimitive adds a named primitive that YAML-defined frameworks can
+// reference from their check list.
+func RegisterPrimitive(name string, p Primitive) {
+	primitiveRegistry[name] = p
+}
+
+// defaultCheckWeight is applied when a YAML check doesn't set an explicit
+// weight, so an unweighted framework scores exactly like an evenly-weighted one.
+const defaultCheckWeight = 1.0
+
+// EvaluateFramework runs every check in f against doc and returns a freshly
+// populated db, the same shape the hardcoded BSI/NTIA/Telco checks build today.
+// Each primitive's raw 0-10 score is multiplied by the check's Weight before
+// being recorded, so a framework author can make one check count for more of
+// the section's score than another; a Weight of 1.0 (the default) leaves the
+// raw score untouched.
+func EvaluateFramework(f Framework, doc sbom.Document) (*db, error) {
+	d := newDB()
+	for _, check := range f.Checks() {
+		primitive, ok := primitiveRegistry[check.Primitive]
+		if !ok {
+			return nil, fmt.Errorf("compliance: framework %s references unknown primitive %q", f.ID(), check.Primitive)
+		}
+
+		weight := check.Weight
+		if weight <= 0 {
+			weight = defaultCheckWeight
+		}
+
+		result, score := primitive(doc, check.Params)
+		d.addRecord(record{check_key: check.SectionKey, id: "doc", check_value: result, score: score * weight})
+	}
+	return d, nil
+}
+
+// constructFrameworkSections turns a populated db into report sections using
+// f's own section metadata, the registry-driven equivalent of constructSections.
+func constructFrameworkSections(f Framework, d *db) []craSection {
+	sectionDetails := f.Sections()
+
+	var sections []craSection
+	for _, id := range d.getAllIds() {
+		for _, r := range d.getRecordsById(id) {
+			meta := sectionDetails[r.check_key]
+			section := craSection{
+				Title:     meta.Title,
+				Id:        meta.Id,
+				DataField: meta.DataField,
+				Required:  meta.Required,
+				Score:     r.score,
+			}
+			if r.id == "doc" {
+				section.ElementId = "sbom"
+			} else {
+				section.ElementId = r.id
+			}
+			section.ElementResult = r.check_value
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// yamlFrameworkSpec is the on-disk shape of a declarative framework file, e.g.
+// for FDA SBOM guidance or an EO 14028 profile that doesn't ship with sbomqs.
+type yamlFrameworkSpec struct {
+	ID       string `yaml:"id"`
+	Sections []struct {
+		Key       int    `yaml:"key"`
+		Title     string `yaml:"title"`
+		Id        string `yaml:"id"`
+		Required  bool   `yaml:"required"`
+		DataField string `yaml:"data_field"`
+	} `yaml:"sections"`
+	Checks []struct {
+		SectionKey int                    `yaml:"section_key"`
+		Primitive  string                 `yaml:"primitive"`
+		Params     map[string]interface{} `yaml:"params"`
+		Weight     float64                `yaml:"weight"`
+	} `yaml:"checks"`
+}
+
+type yamlFramework struct {
+	id       string
+	sections map[int]Section
+	checks   []Check
+}
+
+func (f *yamlFramework) ID() string                { return f.id }
+func (f *yamlFramework) Sections() map[int]Section { return f.sections }
+func (f *yamlFramework) Checks() []Check           { return f.checks }
+
+// LoadFrameworkFromFile parses a declarative framework definition and registers
+// it, so a new compliance standard can be added without forking sbomqs.
+func LoadFrameworkFromFile(path string) (Framework, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("compliance: failed to read framework %s: %w", path, err)
+	}
+
+	var spec yamlFrameworkSpec
+	if err := yaml.Unmarshal(b, &spec); err != nil {
+		return nil, fmt.Errorf("compliance: failed to parse framework %s: %w", path, err)
+	}
+	if spec.ID == "" {
+		return nil, fmt.Errorf("compliance: framework %s is missing an id", path)
+	}
+
+	sections := make(map[int]Section, len(spec.Sections))
+	for _, s := range spec.Sections {
+		sections[s.Key] = Section{Title: s.Title, Id: s.Id, Required: s.Required, DataField: s.DataField}
+	}
+
+	checks := make([]Check, 0, len(spec.Checks))
+	for _, c := range spec.Checks {
+		checks = append(checks, Check{SectionKey: c.SectionKey, Primitive: c.Primitive, Params: c.Params, Weight: c.Weight})
+	}
+
+	f := &yamlFramework{id: spec.ID, sections: sections, checks: checks}
+	Register(f)
+	return f, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/olekukonko/tablewriter"
+)
+
+// FrameworkReport evaluates a registered framework against doc entirely
+// through the Framework interface — Checks(), EvaluateFramework and
+// constructFrameworkSections — and prints a JSON or table report. This is the
+// reachable entry point the registry refactor was for: it's how every
+// YAML-loaded framework (LoadFrameworkFromFile) gets scored and rendered.
+// BSI/NTIA/Telco keep scoring through their own craChecks/ntiaChecks/telcoChecks
+// paths (craJsonReport and friends) since that's where their standard-specific
+// logic already lives; they only expose themselves through the registry for
+// discovery. A framework registered with a non-empty Checks() list, built-in
+// or not, runs through this path.
+func FrameworkReport(id string, doc sbom.Document, fileName, outFormat string) error {
+	f, ok := GetFramework(id)
+	if !ok {
+		return fmt.Errorf("compliance: unknown framework %q", id)
+	}
+
+	d, err := EvaluateFramework(f, doc)
+	if err != nil {
+		return err
+	}
+
+	sections := constructFrameworkSections(f, d)
+	summary := summaryFromSections(sections)
+
+	if outFormat == "json" {
+		o, _ := json.MarshalIndent(struct {
+			Framework string       `json:"framework"`
+			FileName  string       `json:"file_name"`
+			Summary   Summary      `json:"summary"`
+			Sections  []craSection `json:"sections"`
+		}{id, fileName, summary, sections}, "", "  ")
+		fmt.Println(string(o))
+		return nil
+	}
+
+	printFrameworkTable(id, fileName, summary, sections)
+	return nil
+}
+
+// summaryFromSections averages a framework's rendered sections into a Summary,
+// independent of any hardcoded aggregate-score type, since registry-driven
+// frameworks never build one of those.
+func summaryFromSections(sections []craSection) Summary {
+	var total, required, optional float64
+	var requiredCount, optionalCount int
+
+	for _, s := range sections {
+		total += s.Score
+		if s.Required {
+			required += s.Score
+			requiredCount++
+		} else {
+			optional += s.Score
+			optionalCount++
+		}
+	}
+
+	return Summary{
+		MaxScore:           10.0,
+		TotalScore:         average(total, len(sections)),
+		TotalRequiredScore: average(required, requiredCount),
+		TotalOptionalScore: average(optional, optionalCount),
+	}
+}
+
+func average(sum float64, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
+
+func printFrameworkTable(id, fileName string, summary Summary, sections []craSection) {
+	fmt.Printf("%s compliance report for %s\n", id, fileName)
+	fmt.Printf("Score:%0.1f RequiredScore:%0.1f OptionalScore:%0.1f\n", summary.TotalScore, summary.TotalRequiredScore, summary.TotalOptionalScore)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ElementId", "Section", "Datafield", "Element Result", "Score"})
+	table.SetRowLine(true)
+	table.SetAutoMergeCellsByColumnIndex([]int{0})
+
+	for _, section := range sections {
+		sectionID := section.Id
+		if !section.Required {
+			sectionID = sectionID + "*"
+		}
+		table.Append([]string{section.ElementId, sectionID, section.DataField, section.ElementResult, fmt.Sprintf("%0.1f", section.Score)})
+	}
+	table.Render()
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/olekukonko/tablewriter"
+)
+
+// Supported framework names for RunAll. These match the values accepted by the
+// individual `--compliance` flags used throughout the rest of the package.
+const (
+	FrameworkBSI   = "bsi"
+	FrameworkNTIA  = "ntia"
+	FrameworkTelco = "telco"
+)
+
+// complianceScore is satisfied by the per-framework score types returned from
+// craAggregateScore, ntiaAggregateScore and telcoAggregateScore, letting RunAll
+// build a Summary without caring which framework produced it.
+type complianceScore interface {
+	totalScore() float64
+	totalRequiredScore() float64
+	totalOptionalScore() float64
+}
+
+// MultiReport is the result of running one or more compliance frameworks against
+// the same SBOM in a single pass. Each framework gets its own Summary so callers
+// can see where the composite score came from.
+type MultiReport struct {
+	FileName       string             `json:"file_name"`
+	Frameworks     []string           `json:"frameworks"`
+	Summaries      map[string]Summary `json:"summaries"`
+	CompositeScore float64            `json:"composite_score"`
+}
+
+// RunAll evaluates doc against every framework in frameworks, giving each
+// framework its own db (see the per-iteration comment below for why) while
+// still parsing doc itself only once, and emits one combined report in the
+// requested format ("json" or "table"). An empty frameworks slice runs BSI,
+// NTIA and Telco together. Unknown framework names are returned as an error
+// rather than silently skipped.
+func RunAll(doc sbom.Document, fileName string, frameworks []string, outFormat string) error {
+	if len(frameworks) == 0 {
+		frameworks = []string{FrameworkBSI, FrameworkNTIA, FrameworkTelco}
+	}
+
+	report := MultiReport{
+		FileName:  fileName,
+		Summaries: map[string]Summary{},
+	}
+
+	for _, fw := range frameworks {
+		var score complianceScore
+
+		// Deliberately one db per framework, not one shared across the loop:
+		// BSI, NTIA and Telco all reuse the same check_key constants (SBOM_SPEC,
+		// COMP_HASH, ...), so a shared db would let a later framework's
+		// aggregate double-count an earlier framework's records under the same
+		// keys. doc is still parsed only once, by the caller; each framework's
+		// checks walk that same parsed doc on their own, and each records into
+		// its own db.
+		d := newDB()
+
+		switch fw {
+		case FrameworkBSI:
+			craChecks(doc, d)
+			score = craAggregateScore(d)
+		case FrameworkNTIA:
+			ntiaChecks(doc, d)
+			score = ntiaAggregateScore(d)
+		case FrameworkTelco:
+			telcoChecks(doc, d)
+			score = telcoAggregateScore(d)
+		default:
+			return fmt.Errorf("compliance: unknown framework %q", fw)
+		}
+
+		report.Frameworks = append(report.Frameworks, fw)
+		report.Summaries[fw] = Summary{
+			MaxScore:           10.0,
+			TotalScore:         score.totalScore(),
+			TotalRequiredScore: score.totalRequiredScore(),
+			TotalOptionalScore: score.totalOptionalScore(),
+		}
+	}
+
+	var total float64
+	for _, s := range report.Summaries {
+		total += s.TotalScore
+	}
+	report.CompositeScore = total / float64(len(report.Summaries))
+
+	if outFormat == "json" {
+		o, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(o))
+		return nil
+	}
+
+	printMultiReport(report)
+	return nil
+}
+
+func printMultiReport(report MultiReport) {
+	fmt.Printf("Combined compliance report for %s\n", report.FileName)
+	fmt.Printf("Composite score: %0.1f\n", report.CompositeScore)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Framework", "Score", "Required", "Optional"})
+	for _, fw := range report.Frameworks {
+		s := report.Summaries[fw]
+		table.Append([]string{fw, fmt.Sprintf("%0.1f", s.TotalScore), fmt.Sprintf("%0.1f", s.TotalRequiredScore), fmt.Sprintf("%0.1f", s.TotalOptionalScore)})
+	}
+	table.Render()
+}
@@ -0,0 +1,270 @@
+// Copyright 2024 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/yaml.v3"
+)
+
+// PolicyFailExitCode is returned by the sbomqs process when a compliance report
+// fails its policy gate, so CI can key off it instead of grepping output.
+const PolicyFailExitCode = 2
+
+// Policy describes the pass/fail thresholds a compliance report must clear to
+// be considered CI-green.
+type Policy struct {
+	MinRequiredScore float64  `yaml:"min_required_score"`
+	MinTotalScore    float64  `yaml:"min_total_score"`
+	RequiredChecks   []string `yaml:"required_checks"`
+}
+
+// Violation is one policy rule that a compliance report failed to satisfy.
+type Violation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// PolicyResult is the outcome of evaluating a Policy against a built db. It is
+// embedded in the JSON report and rendered alongside the table report.
+type PolicyResult struct {
+	Passed     bool        `json:"passed"`
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+// LoadPolicy reads and parses a Policy from a YAML file.
+func LoadPolicy(path string) (*Policy, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("compliance: failed to read policy %s: %w", path, err)
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(b, &p); err != nil {
+		return nil, fmt.Errorf("compliance: failed to parse policy %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// Evaluate checks score and sections against p and reports every violated rule,
+// rather than stopping at the first failure, so a single CI run surfaces
+// everything that needs fixing.
+func (p *Policy) Evaluate(score complianceScore, sections []craSection) PolicyResult {
+	var violations []Violation
+
+	if p.MinRequiredScore > 0 && score.totalRequiredScore() < p.MinRequiredScore {
+		violations = append(violations, Violation{
+			Rule:    "min_required_score",
+			Message: fmt.Sprintf("required elements score %0.1f is below the minimum of %0.1f", score.totalRequiredScore(), p.MinRequiredScore),
+		})
+	}
+
+	if p.MinTotalScore > 0 && score.totalScore() < p.MinTotalScore {
+		violations = append(violations, Violation{
+			Rule:    "min_total_score",
+			Message: fmt.Sprintf("total score %0.1f is below the minimum of %0.1f", score.totalScore(), p.MinTotalScore),
+		})
+	}
+
+	for _, check := range p.RequiredChecks {
+		if !sectionsHaveNonZeroScore(sections, check) {
+			violations = append(violations, Violation{
+				Rule:    "required_checks",
+				Message: fmt.Sprintf("required check %s scored 0", check),
+			})
+		}
+	}
+
+	return PolicyResult{
+		Passed:     len(violations) == 0,
+		Violations: violations,
+	}
+}
+
+// sectionsHaveNonZeroScore matches a policy's required_checks entry against a
+// report's sections. BSI sections resolve to their exact constant name (e.g.
+// "COMP_HASH", matching the examples in BSI policy files); sections from any
+// other registered framework fall back to a slug of their DataField, so
+// --policy isn't limited to BSI reports, just named a little less tersely for
+// NTIA/Telco until those frameworks grow their own named constants.
+func sectionsHaveNonZeroScore(sections []craSection, checkName string) bool {
+	for _, section := range sections {
+		if craCheckKeyNameByDataField(section.DataField) == checkName && section.Score > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// craJsonReportWithPolicy is craJsonReport plus a PolicyResult block, for the
+// `--policy` CI-gating mode.
+func craJsonReportWithPolicy(db *db, fileName string, policy *Policy) PolicyResult {
+	jr := newJsonReport()
+	jr.Run.FileName = fileName
+
+	score := craAggregateScore(db)
+	jr.Summary = Summary{
+		MaxScore:           10.0,
+		TotalScore:         score.totalScore(),
+		TotalRequiredScore: score.totalRequiredScore(),
+		TotalOptionalScore: score.totalOptionalScore(),
+	}
+	jr.Sections = constructSections(db)
+
+	result := policy.Evaluate(score, jr.Sections)
+
+	o, _ := json.MarshalIndent(struct {
+		*craComplianceReport
+		Policy PolicyResult `json:"policy_result"`
+	}{jr, result}, "", "  ")
+	fmt.Println(string(o))
+
+	return result
+}
+
+// craDetailedReportWithPolicy is craDetailedReport plus a violations table
+// printed underneath, for the `--policy` CI-gating mode.
+func craDetailedReportWithPolicy(db *db, fileName string, policy *Policy) PolicyResult {
+	craDetailedReport(db, fileName)
+
+	score := craAggregateScore(db)
+	result := policy.Evaluate(score, constructSections(db))
+	printPolicyViolations(result)
+	return result
+}
+
+// genericJsonReportWithPolicy renders fileName's score/sections for a framework
+// that has no dedicated *ComplianceReport type of its own (NTIA, Telco), plus a
+// PolicyResult block, so `--policy` isn't limited to BSI reports.
+func genericJsonReportWithPolicy(framework, fileName string, score complianceScore, sections []craSection, policy *Policy) PolicyResult {
+	result := policy.Evaluate(score, sections)
+
+	o, _ := json.MarshalIndent(struct {
+		Framework string       `json:"framework"`
+		FileName  string       `json:"file_name"`
+		Summary   Summary      `json:"summary"`
+		Sections  []craSection `json:"sections"`
+		Policy    PolicyResult `json:"policy_result"`
+	}{
+		Framework: framework,
+		FileName:  fileName,
+		Summary: Summary{
+			MaxScore:           10.0,
+			TotalScore:         score.totalScore(),
+			TotalRequiredScore: score.totalRequiredScore(),
+			TotalOptionalScore: score.totalOptionalScore(),
+		},
+		Sections: sections,
+		Policy:   result,
+	}, "", "  ")
+	fmt.Println(string(o))
+
+	return result
+}
+
+// ntiaJsonReportWithPolicy is ntiaJsonReport plus a PolicyResult block.
+func ntiaJsonReportWithPolicy(db *db, fileName string, policy *Policy) PolicyResult {
+	score := ntiaAggregateScore(db)
+	return genericJsonReportWithPolicy(FrameworkNTIA, fileName, score, constructNtiaSections(db), policy)
+}
+
+// ntiaDetailedReportWithPolicy is ntiaDetailedReport plus a violations table
+// printed underneath.
+func ntiaDetailedReportWithPolicy(db *db, fileName string, policy *Policy) PolicyResult {
+	ntiaDetailedReport(db, fileName)
+
+	score := ntiaAggregateScore(db)
+	result := policy.Evaluate(score, constructNtiaSections(db))
+	printPolicyViolations(result)
+	return result
+}
+
+// telcoJsonReportWithPolicy is telcoJsonReport plus a PolicyResult block.
+func telcoJsonReportWithPolicy(db *db, fileName string, policy *Policy) PolicyResult {
+	score := telcoAggregateScore(db)
+	return genericJsonReportWithPolicy(FrameworkTelco, fileName, score, constructTelcoSections(db), policy)
+}
+
+// telcoDetailedReportWithPolicy is telcoDetailedReport plus a violations table
+// printed underneath.
+func telcoDetailedReportWithPolicy(db *db, fileName string, policy *Policy) PolicyResult {
+	telcoDetailedReport(db, fileName)
+
+	score := telcoAggregateScore(db)
+	result := policy.Evaluate(score, constructTelcoSections(db))
+	printPolicyViolations(result)
+	return result
+}
+
+// Result is the `--policy` entry point cmd calls in place of the plain
+// craJsonReport/ntiaJsonReport/telcoJsonReport/... family: it builds a fresh
+// db, checks doc against framework, renders the report in outFormat, and - on
+// a failed policy - exits the process with PolicyFailExitCode so CI can key
+// off the exit code instead of grepping output.
+func Result(doc sbom.Document, framework, fileName, outFormat string, policy *Policy) {
+	d := newDB()
+
+	var result PolicyResult
+	switch framework {
+	case FrameworkBSI:
+		craChecks(doc, d)
+		if outFormat == "json" {
+			result = craJsonReportWithPolicy(d, fileName, policy)
+		} else {
+			result = craDetailedReportWithPolicy(d, fileName, policy)
+		}
+	case FrameworkNTIA:
+		ntiaChecks(doc, d)
+		if outFormat == "json" {
+			result = ntiaJsonReportWithPolicy(d, fileName, policy)
+		} else {
+			result = ntiaDetailedReportWithPolicy(d, fileName, policy)
+		}
+	case FrameworkTelco:
+		telcoChecks(doc, d)
+		if outFormat == "json" {
+			result = telcoJsonReportWithPolicy(d, fileName, policy)
+		} else {
+			result = telcoDetailedReportWithPolicy(d, fileName, policy)
+		}
+	default:
+		fmt.Printf("compliance: unknown framework %q\n", framework)
+		os.Exit(PolicyFailExitCode)
+	}
+
+	if !result.Passed {
+		os.Exit(PolicyFailExitCode)
+	}
+}
+
+func printPolicyViolations(result PolicyResult) {
+	if result.Passed {
+		fmt.Println("Policy: PASSED")
+		return
+	}
+
+	fmt.Println("Policy: FAILED")
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Rule", "Violation"})
+	for _, v := range result.Violations {
+		table.Append([]string{v.Rule, v.Message})
+	}
+	table.Render()
+}
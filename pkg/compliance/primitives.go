@@ -0,0 +1,64 @@
+// Copyright 2024 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"fmt"
+
+	"github.com/interlynk-io/sbomqs/pkg/sbom"
+)
+
+// primaryComponentHasHash reports whether the SBOM's primary component carries
+// at least one hash value, the same element BSI 5.2.2 (COMP_HASH) checks for.
+func primaryComponentHasHash(doc sbom.Document, _ map[string]interface{}) (string, float64) {
+	pc := doc.PrimaryComponent()
+	if pc == nil || len(pc.GetChecksums()) == 0 {
+		return "no component hash found", 0
+	}
+	return "component hash found", 10
+}
+
+// specVersionIn reports whether the SBOM's declared spec version is one of the
+// values listed in params["versions"].
+func specVersionIn(doc sbom.Document, params map[string]interface{}) (string, float64) {
+	allowed, _ := params["versions"].([]interface{})
+	version := doc.Spec().GetVersion()
+
+	for _, v := range allowed {
+		if fmt.Sprintf("%v", v) == version {
+			return fmt.Sprintf("spec version %s is allowed", version), 10
+		}
+	}
+	return fmt.Sprintf("spec version %s is not in the allowed list", version), 0
+}
+
+// componentLicensePresent reports the fraction of components that declare a
+// license, scored out of 10.
+func componentLicensePresent(doc sbom.Document, _ map[string]interface{}) (string, float64) {
+	components := doc.Components()
+	if len(components) == 0 {
+		return "no components found", 0
+	}
+
+	withLicense := 0
+	for _, c := range components {
+		if len(c.GetLicenses()) > 0 {
+			withLicense++
+		}
+	}
+
+	score := 10 * float64(withLicense) / float64(len(components))
+	return fmt.Sprintf("%d/%d components have a license", withLicense, len(components)), score
+}
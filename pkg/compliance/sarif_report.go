@@ -0,0 +1,316 @@
+// Copyright 2024 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// sarifMaxScore is the score a fully-satisfied section carries, matching
+// Summary.MaxScore.
+const sarifMaxScore = 10.0
+
+const sarifSchemaURI = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+
+// sarifLog is a minimal SARIF 2.1.0 log, just enough of the spec for a single
+// tool run of compliance checks against one SBOM.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string         `json:"id"`
+	ShortDescription sarifMultiText `json:"shortDescription"`
+	FullDescription  sarifMultiText `json:"fullDescription"`
+	HelpURI          string         `json:"helpUri"`
+}
+
+type sarifMultiText struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMultiText  `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// craCheckKeyNames maps each craSectionDetails key to the identifier used in its
+// SARIF ruleId, e.g. "BSI-5.2.2-COMP_HASH". Kept as a lookup rather than a
+// stringer on the check-key constants since the ids need to stay stable even if
+// the constants are renumbered.
+var craCheckKeyNames = map[int]string{
+	SBOM_SPEC:            "SBOM_SPEC",
+	SBOM_SPEC_VERSION:    "SBOM_SPEC_VERSION",
+	SBOM_BUILD:           "SBOM_BUILD",
+	SBOM_DEPTH:           "SBOM_DEPTH",
+	SBOM_CREATOR:         "SBOM_CREATOR",
+	SBOM_TIMESTAMP:       "SBOM_TIMESTAMP",
+	SBOM_COMPONENTS:      "SBOM_COMPONENTS",
+	SBOM_URI:             "SBOM_URI",
+	COMP_CREATOR:         "COMP_CREATOR",
+	COMP_NAME:            "COMP_NAME",
+	COMP_VERSION:         "COMP_VERSION",
+	COMP_DEPTH:           "COMP_DEPTH",
+	COMP_LICENSE:         "COMP_LICENSE",
+	COMP_HASH:            "COMP_HASH",
+	COMP_SOURCE_CODE_URL: "COMP_SOURCE_CODE_URL",
+	COMP_DOWNLOAD_URL:    "COMP_DOWNLOAD_URL",
+	COMP_SOURCE_HASH:     "COMP_SOURCE_HASH",
+	COMP_OTHER_UNIQ_IDS:  "COMP_OTHER_UNIQ_IDS",
+}
+
+// sarifSectionInfo is the section metadata buildSarifRules needs, lifted out of
+// whichever framework-specific section-detail map (craSectionDetails,
+// ntiaSectionDetails, telcoSectionDetails) it came from so the rule-building
+// logic itself doesn't need to know their concrete types.
+type sarifSectionInfo struct {
+	key       int
+	Id        string
+	Title     string
+	DataField string
+}
+
+// buildSarifRules builds a full run.tool.driver.rules array from infos,
+// independent of which elements a given SBOM actually triggered - GitHub's
+// code-scanning UI expects every rule a tool can produce, not just the ones
+// that fired on this run.
+func buildSarifRules(prefix string, infos []sarifSectionInfo, descFmt, helpURI string, keyName func(sarifSectionInfo) string) []sarifRule {
+	rules := make([]sarifRule, 0, len(infos))
+	for _, info := range infos {
+		id := sarifRuleID(prefix, info.Id, keyName(info))
+		rules = append(rules, sarifRule{
+			ID:               id,
+			ShortDescription: sarifMultiText{Text: fmt.Sprintf("%s: %s", info.Title, info.DataField)},
+			FullDescription:  sarifMultiText{Text: fmt.Sprintf(descFmt, info.Id, info.DataField)},
+			HelpURI:          helpURI,
+		})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return rules
+}
+
+// craSarifRules builds the run.tool.driver.rules array once from craSectionDetails.
+func craSarifRules() []sarifRule {
+	infos := make([]sarifSectionInfo, 0, len(craSectionDetails))
+	for key, section := range craSectionDetails {
+		infos = append(infos, sarifSectionInfo{key: key, Id: section.Id, Title: section.Title, DataField: section.DataField})
+	}
+	return buildSarifRules("BSI", infos, "TR-03183-2 section %s requires %s.", "https://www.bsi.bund.de/dok/TR-03183-2",
+		func(info sarifSectionInfo) string { return craCheckKeyNames[info.key] })
+}
+
+// ntiaSarifRules builds the run.tool.driver.rules array once from ntiaSectionDetails.
+func ntiaSarifRules() []sarifRule {
+	infos := make([]sarifSectionInfo, 0, len(ntiaSectionDetails))
+	for key, section := range ntiaSectionDetails {
+		infos = append(infos, sarifSectionInfo{key: key, Id: section.Id, Title: section.Title, DataField: section.DataField})
+	}
+	return buildSarifRules("NTIA", infos, "NTIA minimum elements section %s requires %s.", "https://www.ntia.gov/SBOM",
+		func(info sarifSectionInfo) string { return sarifKeyFromDataField(info.DataField) })
+}
+
+// telcoSarifRules builds the run.tool.driver.rules array once from telcoSectionDetails.
+func telcoSarifRules() []sarifRule {
+	infos := make([]sarifSectionInfo, 0, len(telcoSectionDetails))
+	for key, section := range telcoSectionDetails {
+		infos = append(infos, sarifSectionInfo{key: key, Id: section.Id, Title: section.Title, DataField: section.DataField})
+	}
+	return buildSarifRules("TELCO", infos, "OpenChain Telco SBOM Guide section %s requires %s.", "https://github.com/OpenChain-Project/Telco-SBOM-Guide",
+		func(info sarifSectionInfo) string { return sarifKeyFromDataField(info.DataField) })
+}
+
+func sarifRuleID(prefix, sectionID, key string) string {
+	if key == "" {
+		key = "UNKNOWN"
+	}
+	return fmt.Sprintf("%s-%s-%s", prefix, sectionID, key)
+}
+
+func sarifLevel(required bool) string {
+	if required {
+		return "error"
+	}
+	return "warning"
+}
+
+// sarifSectionFails reports whether section should surface as a SARIF result:
+// a required element that didn't score full marks, or an optional element
+// that's missing entirely. A fully-satisfied SBOM should produce zero results.
+func sarifSectionFails(section craSection) bool {
+	if section.Required {
+		return section.Score < sarifMaxScore
+	}
+	return section.Score == 0
+}
+
+// craSarifReport renders the BSI TR-03183-2 compliance results as SARIF 2.1.0 so
+// they can be uploaded via github/codeql-action/upload-sarif and show up in the
+// GitHub Security tab next to other scanner findings.
+func craSarifReport(db *db, fileName string) {
+	sections := constructSections(db)
+
+	var results []sarifResult
+	for _, section := range sections {
+		if !sarifSectionFails(section) {
+			continue
+		}
+		key := craCheckKeyNameByDataField(section.DataField)
+		results = append(results, sarifResult{
+			RuleID:  sarifRuleID("BSI", section.Id, key),
+			Level:   sarifLevel(section.Required),
+			Message: sarifMultiText{Text: fmt.Sprintf("%s (%s): %s", section.Title, section.DataField, section.ElementResult)},
+			Locations: []sarifLocation{
+				{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: fileName}}},
+			},
+		})
+	}
+
+	printSarifLog(craSarifRules(), results)
+}
+
+// ntiaSarifReport renders the NTIA minimum elements results as SARIF 2.1.0.
+func ntiaSarifReport(db *db, fileName string) {
+	sections := constructNtiaSections(db)
+
+	var results []sarifResult
+	for _, section := range sections {
+		if !sarifSectionFails(section) {
+			continue
+		}
+		key := sarifKeyFromDataField(section.DataField)
+		results = append(results, sarifResult{
+			RuleID:  sarifRuleID("NTIA", section.Id, key),
+			Level:   sarifLevel(section.Required),
+			Message: sarifMultiText{Text: fmt.Sprintf("%s (%s): %s", section.Title, section.DataField, section.ElementResult)},
+			Locations: []sarifLocation{
+				{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: fileName}}},
+			},
+		})
+	}
+
+	printSarifLog(ntiaSarifRules(), results)
+}
+
+// telcoSarifReport renders the OpenChain Telco (Doc 1/2/10) results as SARIF 2.1.0.
+func telcoSarifReport(db *db, fileName string) {
+	sections := constructTelcoSections(db)
+
+	var results []sarifResult
+	for _, section := range sections {
+		if !sarifSectionFails(section) {
+			continue
+		}
+		key := sarifKeyFromDataField(section.DataField)
+		results = append(results, sarifResult{
+			RuleID:  sarifRuleID("TELCO", section.Id, key),
+			Level:   sarifLevel(section.Required),
+			Message: sarifMultiText{Text: fmt.Sprintf("%s (%s): %s", section.Title, section.DataField, section.ElementResult)},
+			Locations: []sarifLocation{
+				{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: fileName}}},
+			},
+		})
+	}
+
+	printSarifLog(telcoSarifRules(), results)
+}
+
+// SarifReport is the reachable entry point cmd calls for `--format sarif`: it
+// dispatches to the BSI/NTIA/Telco SARIF renderer matching framework, the same
+// three-way switch RunAll and Result already use to pick a framework's checks.
+func SarifReport(framework string, db *db, fileName string) error {
+	switch framework {
+	case FrameworkBSI:
+		craSarifReport(db, fileName)
+	case FrameworkNTIA:
+		ntiaSarifReport(db, fileName)
+	case FrameworkTelco:
+		telcoSarifReport(db, fileName)
+	default:
+		return fmt.Errorf("compliance: unknown framework %q", framework)
+	}
+	return nil
+}
+
+func sarifKeyFromDataField(dataField string) string {
+	return strings.ToUpper(strings.ReplaceAll(dataField, " ", "_"))
+}
+
+func printSarifLog(rules []sarifRule, results []sarifResult) {
+	log := sarifLog{
+		Schema:  sarifSchemaURI,
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "sbomqs",
+						InformationURI: "https://github.com/interlynk-io/sbomqs",
+						Rules:          rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+
+	o, _ := json.MarshalIndent(log, "", "  ")
+	fmt.Println(string(o))
+}
+
+// craCheckKeyNameByDataField recovers the check-key name for a rendered section,
+// since constructSections only carries the human-readable fields through to the
+// report. Falls back to a slugified DataField for entries without a known key.
+func craCheckKeyNameByDataField(dataField string) string {
+	for key, section := range craSectionDetails {
+		if section.DataField == dataField {
+			return craCheckKeyNames[key]
+		}
+	}
+	return sarifKeyFromDataField(dataField)
+}
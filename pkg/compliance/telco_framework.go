@@ -0,0 +1,37 @@
+// Copyright 2024 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+// telcoFramework exposes the OpenChain Telco (Doc 1/2/10) standard through the
+// Framework registry, the same way craFramework does for BSI. Its checks stay
+// on the existing hardcoded telcoChecks path rather than the primitive
+// registry; only its section metadata is shared.
+type telcoFramework struct{}
+
+func (telcoFramework) ID() string { return FrameworkTelco }
+
+func (telcoFramework) Sections() map[int]Section {
+	sections := make(map[int]Section, len(telcoSectionDetails))
+	for key, s := range telcoSectionDetails {
+		sections[key] = Section{Title: s.Title, Id: s.Id, Required: s.Required, DataField: s.DataField}
+	}
+	return sections
+}
+
+func (telcoFramework) Checks() []Check { return nil }
+
+func init() {
+	Register(telcoFramework{})
+}